@@ -21,176 +21,335 @@ package sdk
 
 import (
 	"bytes"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 )
 
+// BoolString represents a value that may be a JSON bool or a JSON string,
+// e.g. `true` or `"enabled"`. The string half is modelled on sql.NullString,
+// so s.String and s.Valid hold the string variant.
 type BoolString struct {
-	Flag  bool
-	Value string
+	sql.NullString
+	Flag bool
 }
 
-func (s *BoolString) UnmarshalJSON(raw []byte) error {
-	if raw == nil || bytes.Equal(raw, []byte(`"null"`)) {
-		return nil
+// BoolStringFrom creates a valid BoolString holding a string value.
+func BoolStringFrom(s string) BoolString {
+	return BoolString{NullString: sql.NullString{String: s, Valid: true}}
+}
+
+// BoolStringFromPtr creates a BoolString from a *string, returning a zero
+// BoolString if s is nil.
+func BoolStringFromPtr(s *string) BoolString {
+	if s == nil {
+		return BoolString{}
 	}
-	var (
-		tmp string
-		err error
-	)
-	if raw[0] != '"' {
-		if bytes.Equal(raw, []byte("true")) {
-			s.Flag = true
-			return nil
-		}
-		if bytes.Equal(raw, []byte("false")) {
-			return nil
-		}
-		return errors.New("bad boolean value provided")
+	return BoolStringFrom(*s)
+}
+
+// IsZero reports whether s holds neither a true flag nor a valid string.
+func (s BoolString) IsZero() bool {
+	return !s.Flag && !s.Valid
+}
+
+// ValueOrZero returns s.String, or the empty string if s is not valid.
+func (s BoolString) ValueOrZero() string {
+	if !s.Valid {
+		return ""
 	}
-	if err = json.Unmarshal(raw, &tmp); err != nil {
+	return s.String
+}
+
+func (s *BoolString) UnmarshalJSON(raw []byte) error {
+	kind, val, err := scanScalar(raw)
+	if err != nil {
 		return err
 	}
-	s.Value = tmp
+	switch kind {
+	case ScalarNull:
+		*s = BoolString{}
+	case ScalarBool:
+		s.Flag = bytes.Equal(val, []byte("true"))
+	case ScalarString:
+		s.String, s.Valid = string(val), true
+	default:
+		return errors.New("bad boolean value provided")
+	}
 	return nil
 }
 
 func (s BoolString) MarshalJSON() ([]byte, error) {
-	if s.Value != "" {
+	if s.Valid {
 		var buf bytes.Buffer
 		buf.WriteRune('"')
-		buf.WriteString(s.Value)
+		buf.WriteString(s.String)
 		buf.WriteRune('"')
 		return buf.Bytes(), nil
 	}
 	return strconv.AppendBool([]byte{}, s.Flag), nil
 }
 
+// Scan implements the sql.Scanner interface.
+func (s *BoolString) Scan(value interface{}) error {
+	if value == nil {
+		*s = BoolString{}
+		return nil
+	}
+	switch v := value.(type) {
+	case bool:
+		*s = BoolString{Flag: v}
+	case string:
+		*s = BoolStringFrom(v)
+	case []byte:
+		*s = BoolStringFrom(string(v))
+	default:
+		return fmt.Errorf("sdk: cannot scan type %T into BoolString", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (s BoolString) Value() (driver.Value, error) {
+	if s.Valid {
+		return s.String, nil
+	}
+	return s.Flag, nil
+}
+
+// BoolInt represents a value that may be a JSON bool or a JSON (possibly
+// quoted) integer, e.g. `true` or `"100"`.
 type BoolInt struct {
-	Flag  bool
-	Value *int64
+	sql.NullInt64
+	Flag bool
+}
+
+// BoolIntFrom creates a valid BoolInt holding an int64 value.
+func BoolIntFrom(i int64) BoolInt {
+	return BoolInt{NullInt64: sql.NullInt64{Int64: i, Valid: true}}
+}
+
+// BoolIntFromPtr creates a BoolInt from a *int64, returning a zero BoolInt
+// if i is nil.
+func BoolIntFromPtr(i *int64) BoolInt {
+	if i == nil {
+		return BoolInt{}
+	}
+	return BoolIntFrom(*i)
+}
+
+// IsZero reports whether s holds neither a true flag nor a valid int64.
+func (s BoolInt) IsZero() bool {
+	return !s.Flag && !s.Valid
+}
+
+// ValueOrZero returns s.Int64, or 0 if s is not valid.
+func (s BoolInt) ValueOrZero() int64 {
+	if !s.Valid {
+		return 0
+	}
+	return s.Int64
 }
 
 func (s *BoolInt) UnmarshalJSON(raw []byte) error {
-	if raw == nil || bytes.Equal(raw, []byte(`"null"`)) {
-		return nil
+	kind, val, err := scanScalar(raw)
+	if err != nil {
+		return err
 	}
-	var (
-		tmp int64
-		err error
-	)
-	if tmp, err = strconv.ParseInt(string(raw), 10, 64); err != nil {
-		if bytes.Equal(raw, []byte("true")) {
-			s.Flag = true
-			return nil
-		}
-		if bytes.Equal(raw, []byte("false")) {
-			return nil
+	switch kind {
+	case ScalarNull:
+		*s = BoolInt{}
+	case ScalarBool:
+		s.Flag = bytes.Equal(val, []byte("true"))
+	case ScalarNumber, ScalarString:
+		i, err := strconv.ParseInt(string(val), 10, 64)
+		if err != nil {
+			return errors.New("bad value provided")
 		}
+		s.Int64, s.Valid = i, true
+	default:
 		return errors.New("bad value provided")
 	}
-	s.Value = &tmp
 	return nil
 }
 
 func (s BoolInt) MarshalJSON() ([]byte, error) {
-	if s.Value != nil {
-		return strconv.AppendInt([]byte{}, *s.Value, 10), nil
+	if s.Valid {
+		return strconv.AppendInt([]byte{}, s.Int64, 10), nil
 	}
 	return strconv.AppendBool([]byte{}, s.Flag), nil
 }
 
+// Scan implements the sql.Scanner interface.
+func (s *BoolInt) Scan(value interface{}) error {
+	if value == nil {
+		*s = BoolInt{}
+		return nil
+	}
+	switch v := value.(type) {
+	case bool:
+		*s = BoolInt{Flag: v}
+	case int64:
+		*s = BoolIntFrom(v)
+	case []byte:
+		i, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return fmt.Errorf("sdk: cannot scan %q into BoolInt: %w", v, err)
+		}
+		*s = BoolIntFrom(i)
+	default:
+		return fmt.Errorf("sdk: cannot scan type %T into BoolInt", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (s BoolInt) Value() (driver.Value, error) {
+	if s.Valid {
+		return s.Int64, nil
+	}
+	return s.Flag, nil
+}
+
 func NewIntString(i int64) *IntString {
-	return &IntString{
-		Value: i,
-		Valid: true,
+	v := IntStringFrom(i)
+	return &v
+}
+
+// IntStringFrom creates a valid IntString holding i.
+func IntStringFrom(i int64) IntString {
+	return IntString{sql.NullInt64{Int64: i, Valid: true}}
+}
+
+// IntStringFromPtr creates an IntString from a *int64, returning a zero
+// (invalid) IntString if i is nil.
+func IntStringFromPtr(i *int64) IntString {
+	if i == nil {
+		return IntString{}
 	}
+	return IntStringFrom(*i)
 }
 
 // IntString represents special type for json values that could be strings or ints: 100 or "100"
 type IntString struct {
-	Value int64
-	Valid bool
+	sql.NullInt64
+}
+
+// IsZero reports whether v is not valid.
+func (v IntString) IsZero() bool {
+	return !v.Valid
+}
+
+// ValueOrZero returns v.Int64, or 0 if v is not valid.
+func (v IntString) ValueOrZero() int64 {
+	if !v.Valid {
+		return 0
+	}
+	return v.Int64
 }
 
 // UnmarshalJSON implements custom unmarshalling for IntString type
 func (v *IntString) UnmarshalJSON(raw []byte) error {
-	if raw == nil || bytes.Equal(raw, []byte(`"null"`)) || bytes.Equal(raw, []byte(`""`)) {
-		return nil
+	kind, val, err := scanScalar(raw)
+	if err != nil {
+		return err
 	}
-
-	strVal := string(raw)
-	if rune(raw[0]) == '"' {
-		strVal = strings.Trim(strVal, `"`)
+	if kind == ScalarNull || (kind == ScalarString && len(val) == 0) {
+		*v = IntString{}
+		return nil
 	}
 
-	i, err := strconv.ParseInt(strVal, 10, 64)
+	i, err := strconv.ParseInt(string(val), 10, 64)
 	if err != nil {
 		return err
 	}
 
-	v.Value = i
+	v.Int64 = i
 	v.Valid = true
 
 	return nil
 }
 
 // MarshalJSON implements custom marshalling for IntString type
-func (v *IntString) MarshalJSON() ([]byte, error) {
+func (v IntString) MarshalJSON() ([]byte, error) {
 	if v.Valid {
-		strVal := strconv.FormatInt(v.Value, 10)
+		strVal := strconv.FormatInt(v.Int64, 10)
 		return []byte(strVal), nil
 	}
 
-	return []byte(`"null"`), nil
+	return []byte(`null`), nil
 }
 
 func NewFloatString(i float64) *FloatString {
-	return &FloatString{
-		Value: i,
-		Valid: true,
+	v := FloatStringFrom(i)
+	return &v
+}
+
+// FloatStringFrom creates a valid FloatString holding f.
+func FloatStringFrom(f float64) FloatString {
+	return FloatString{sql.NullFloat64{Float64: f, Valid: true}}
+}
+
+// FloatStringFromPtr creates a FloatString from a *float64, returning a
+// zero (invalid) FloatString if f is nil.
+func FloatStringFromPtr(f *float64) FloatString {
+	if f == nil {
+		return FloatString{}
 	}
+	return FloatStringFrom(*f)
 }
 
 // FloatString represents special type for json values that could be strings or ints: 100 or "100"
 type FloatString struct {
-	Value float64
-	Valid bool
+	sql.NullFloat64
+}
+
+// IsZero reports whether v is not valid.
+func (v FloatString) IsZero() bool {
+	return !v.Valid
+}
+
+// ValueOrZero returns v.Float64, or 0 if v is not valid.
+func (v FloatString) ValueOrZero() float64 {
+	if !v.Valid {
+		return 0
+	}
+	return v.Float64
 }
 
 // UnmarshalJSON implements custom unmarshalling for FloatString type
 func (v *FloatString) UnmarshalJSON(raw []byte) error {
-	if raw == nil || bytes.Equal(raw, []byte(`"null"`)) || bytes.Equal(raw, []byte(`""`)) {
-		return nil
+	kind, val, err := scanScalar(raw)
+	if err != nil {
+		return err
 	}
-
-	strVal := string(raw)
-	if rune(raw[0]) == '"' {
-		strVal = strings.Trim(strVal, `"`)
+	if kind == ScalarNull || (kind == ScalarString && len(val) == 0) {
+		*v = FloatString{}
+		return nil
 	}
 
-	i, err := strconv.ParseFloat(strVal, 64)
+	f, err := strconv.ParseFloat(string(val), 64)
 	if err != nil {
 		return err
 	}
 
-	v.Value = i
+	v.Float64 = f
 	v.Valid = true
 
 	return nil
 }
 
 // MarshalJSON implements custom marshalling for FloatString type
-func (v *FloatString) MarshalJSON() ([]byte, error) {
+func (v FloatString) MarshalJSON() ([]byte, error) {
 	if v.Valid {
-		strVal := strconv.FormatFloat(v.Value, 'g', -1, 64)
+		strVal := strconv.FormatFloat(v.Float64, 'g', -1, 64)
 		return []byte(strVal), nil
 	}
 
-	return []byte(`"null"`), nil
+	return []byte(`null`), nil
 }
 
 type actualType int
@@ -201,49 +360,117 @@ const (
 	actualFloat
 )
 
-// FloatString represents special type for json values that could be strings or floats: "100px" or 100.3.
+// FloatOrString represents special type for json values that could be strings or floats: "100px" or 100.3.
 type FloatOrString struct {
 	FValue float64
 	SValue string
 	actual actualType
 }
 
-// UnmarshalJSON implements custom unmarshalling for FloatString type.
-func (v *FloatOrString) UnmarshalJSON(raw []byte) error {
-	if raw == nil || bytes.Equal(raw, []byte(`"null"`)) || bytes.Equal(raw, []byte(`""`)) {
-		v.actual = actualNull
-		return nil
-	}
+// FloatOrStringFromFloat creates a valid FloatOrString holding f.
+func FloatOrStringFromFloat(f float64) FloatOrString {
+	return FloatOrString{FValue: f, actual: actualFloat}
+}
 
-	strVal := string(raw)
-	if rune(raw[0]) == '"' {
-		strVal = strings.Trim(strVal, `"`)
-		v.actual = actualString
-		v.SValue = strVal
+// FloatOrStringFromString creates a valid FloatOrString holding s.
+func FloatOrStringFromString(s string) FloatOrString {
+	return FloatOrString{SValue: s, actual: actualString}
+}
+
+// IsZero reports whether v holds neither a float nor a string value.
+func (v FloatOrString) IsZero() bool {
+	return v.actual == actualNull
+}
+
+// ValueOrZero returns v's value as a float64, a string, or nil when v is
+// null. Callers that know which kind they expect should read FValue/SValue
+// directly instead.
+func (v FloatOrString) ValueOrZero() interface{} {
+	switch v.actual {
+	case actualFloat:
+		return v.FValue
+	case actualString:
+		return v.SValue
+	default:
 		return nil
 	}
+}
 
-	i, err := strconv.ParseFloat(strVal, 64)
+// UnmarshalJSON implements custom unmarshalling for FloatOrString type.
+func (v *FloatOrString) UnmarshalJSON(raw []byte) error {
+	kind, val, err := scanScalar(raw)
 	if err != nil {
 		return err
 	}
-	v.FValue = i
-	v.actual = actualFloat
-	return nil
+	switch {
+	case kind == ScalarNull || (kind == ScalarString && len(val) == 0):
+		*v = FloatOrString{actual: actualNull}
+		return nil
+	case kind == ScalarString:
+		v.actual = actualString
+		v.SValue = string(val)
+		return nil
+	case kind == ScalarNumber:
+		f, err := strconv.ParseFloat(string(val), 64)
+		if err != nil {
+			return err
+		}
+		v.FValue = f
+		v.actual = actualFloat
+		return nil
+	default:
+		return fmt.Errorf("sdk: bad value provided for FloatOrString")
+	}
 }
 
 // MarshalJSON implements custom marshalling for FloatOrString type.
-func (v *FloatOrString) MarshalJSON() ([]byte, error) {
+func (v FloatOrString) MarshalJSON() ([]byte, error) {
 	switch v.actual {
 	case actualFloat:
 		strVal := strconv.FormatFloat(v.FValue, 'g', -1, 64)
 		return []byte(strVal), nil
 	case actualString:
-		return []byte(v.SValue), nil
+		// SValue must go through json.Marshal: emitting it unquoted
+		// produced invalid JSON whenever it contained a quote or was
+		// otherwise not itself a bare JSON token (e.g. "100px").
+		return json.Marshal(v.SValue)
 	case actualNull:
-		return []byte(`"null"`), nil
+		return []byte(`null`), nil
 	default:
 		// This should never happen.
 		return nil, fmt.Errorf("unknown actual data type for FloatOrString")
 	}
 }
+
+// Scan implements the sql.Scanner interface.
+func (v *FloatOrString) Scan(value interface{}) error {
+	if value == nil {
+		*v = FloatOrString{actual: actualNull}
+		return nil
+	}
+	switch val := value.(type) {
+	case float64:
+		*v = FloatOrStringFromFloat(val)
+	case int64:
+		*v = FloatOrStringFromFloat(float64(val))
+	case string:
+		*v = FloatOrStringFromString(val)
+	case []byte:
+		*v = FloatOrStringFromString(string(val))
+	default:
+		return fmt.Errorf("sdk: cannot scan type %T into FloatOrString", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (v FloatOrString) Value() (driver.Value, error) {
+	switch v.actual {
+	case actualFloat:
+		return v.FValue, nil
+	case actualString:
+		return v.SValue, nil
+	default:
+		return nil, nil
+	}
+}
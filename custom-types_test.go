@@ -0,0 +1,175 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import "testing"
+
+func TestBoolStringScanValue(t *testing.T) {
+	cases := []struct {
+		name    string
+		scan    interface{}
+		want    BoolString
+		wantErr bool
+	}{
+		{"nil", nil, BoolString{}, false},
+		{"bool", true, BoolString{Flag: true}, false},
+		{"string", "enabled", BoolStringFrom("enabled"), false},
+		{"bytes", []byte("enabled"), BoolStringFrom("enabled"), false},
+		{"unsupported", 100, BoolString{}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var v BoolString
+			err := v.Scan(c.scan)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%#v): want error, got none", c.scan)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%#v): %v", c.scan, err)
+			}
+			if v != c.want {
+				t.Fatalf("Scan(%#v) = %+v, want %+v", c.scan, v, c.want)
+			}
+		})
+	}
+
+	val, err := BoolStringFrom("enabled").Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if val != "enabled" {
+		t.Fatalf("Value() = %v, want %q", val, "enabled")
+	}
+	val, err = BoolString{Flag: true}.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if val != true {
+		t.Fatalf("Value() = %v, want true", val)
+	}
+}
+
+func TestBoolIntScanValue(t *testing.T) {
+	cases := []struct {
+		name    string
+		scan    interface{}
+		want    BoolInt
+		wantErr bool
+	}{
+		{"nil", nil, BoolInt{}, false},
+		{"bool", true, BoolInt{Flag: true}, false},
+		{"int64", int64(100), BoolIntFrom(100), false},
+		{"bytes", []byte("100"), BoolIntFrom(100), false},
+		{"bad bytes", []byte("nope"), BoolInt{}, true},
+		{"unsupported", 3.14, BoolInt{}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var v BoolInt
+			err := v.Scan(c.scan)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%#v): want error, got none", c.scan)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%#v): %v", c.scan, err)
+			}
+			if v != c.want {
+				t.Fatalf("Scan(%#v) = %+v, want %+v", c.scan, v, c.want)
+			}
+		})
+	}
+
+	val, err := BoolIntFrom(100).Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if val != int64(100) {
+		t.Fatalf("Value() = %v, want 100", val)
+	}
+	val, err = BoolInt{Flag: true}.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if val != true {
+		t.Fatalf("Value() = %v, want true", val)
+	}
+}
+
+func TestFloatOrStringScanValue(t *testing.T) {
+	cases := []struct {
+		name    string
+		scan    interface{}
+		want    FloatOrString
+		wantErr bool
+	}{
+		{"nil", nil, FloatOrString{actual: actualNull}, false},
+		{"float64", float64(100.3), FloatOrStringFromFloat(100.3), false},
+		{"int64", int64(100), FloatOrStringFromFloat(100), false},
+		{"string", "100px", FloatOrStringFromString("100px"), false},
+		{"bytes", []byte("100px"), FloatOrStringFromString("100px"), false},
+		{"unsupported", true, FloatOrString{}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var v FloatOrString
+			err := v.Scan(c.scan)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%#v): want error, got none", c.scan)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%#v): %v", c.scan, err)
+			}
+			if v != c.want {
+				t.Fatalf("Scan(%#v) = %+v, want %+v", c.scan, v, c.want)
+			}
+		})
+	}
+
+	val, err := FloatOrStringFromFloat(100.3).Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if val != 100.3 {
+		t.Fatalf("Value() = %v, want 100.3", val)
+	}
+	val, err = FloatOrStringFromString("100px").Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if val != "100px" {
+		t.Fatalf("Value() = %v, want %q", val, "100px")
+	}
+	val, err = FloatOrString{actual: actualNull}.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if val != nil {
+		t.Fatalf("Value() = %v, want nil", val)
+	}
+}
@@ -0,0 +1,321 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ScalarKind identifies the JSON token kind a ScalarJSON was decoded from.
+type ScalarKind int
+
+const (
+	// ScalarNull means the value was a JSON null (or absent).
+	ScalarNull ScalarKind = iota
+	// ScalarBool means the value was a JSON true/false.
+	ScalarBool
+	// ScalarNumber means the value was a bare JSON number. A quoted number
+	// such as "100" is a ScalarString.
+	ScalarNumber
+	// ScalarString means the value was a JSON string.
+	ScalarString
+)
+
+// ScalarJSON handles Grafana-style polymorphic JSON scalars: fields that
+// may arrive as a number, a string, a bool, or null (e.g. threshold values,
+// mappings, field overrides, template variable current values). It keeps
+// track of the original token kind so MarshalJSON can round-trip it.
+type ScalarJSON struct {
+	kind ScalarKind
+	b    bool
+	n    json.Number
+	s    string
+}
+
+// Kind reports which JSON token kind v was decoded from.
+func (v ScalarJSON) Kind() ScalarKind {
+	return v.kind
+}
+
+// AsBool returns v's value as a bool. The second return value is false if
+// v is not a ScalarBool.
+func (v ScalarJSON) AsBool() (bool, bool) {
+	if v.kind != ScalarBool {
+		return false, false
+	}
+	return v.b, true
+}
+
+// AsInt64 returns v's value as an int64. The second return value is false
+// if v is not a ScalarNumber or the number cannot be represented as an
+// int64.
+func (v ScalarJSON) AsInt64() (int64, bool) {
+	if v.kind != ScalarNumber {
+		return 0, false
+	}
+	i, err := v.n.Int64()
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+// AsFloat64 returns v's value as a float64. The second return value is
+// false if v is not a ScalarNumber.
+func (v ScalarJSON) AsFloat64() (float64, bool) {
+	if v.kind != ScalarNumber {
+		return 0, false
+	}
+	f, err := v.n.Float64()
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// AsString returns v's value as a string. The second return value is false
+// if v is not a ScalarString.
+func (v ScalarJSON) AsString() (string, bool) {
+	if v.kind != ScalarString {
+		return "", false
+	}
+	return v.s, true
+}
+
+// ScalarJSONFromBool creates a ScalarJSON holding a bool.
+func ScalarJSONFromBool(b bool) ScalarJSON {
+	return ScalarJSON{kind: ScalarBool, b: b}
+}
+
+// ScalarJSONFromInt64 creates a ScalarJSON holding an int64.
+func ScalarJSONFromInt64(i int64) ScalarJSON {
+	return ScalarJSON{kind: ScalarNumber, n: json.Number(strconv.FormatInt(i, 10))}
+}
+
+// ScalarJSONFromFloat64 creates a ScalarJSON holding a float64.
+func ScalarJSONFromFloat64(f float64) ScalarJSON {
+	return ScalarJSON{kind: ScalarNumber, n: json.Number(strconv.FormatFloat(f, 'g', -1, 64))}
+}
+
+// ScalarJSONFromString creates a ScalarJSON holding a string.
+func ScalarJSONFromString(s string) ScalarJSON {
+	return ScalarJSON{kind: ScalarString, s: s}
+}
+
+// UnmarshalJSON decodes raw via the shared scanScalar tokenizer, the same
+// one used by BoolString, BoolInt, IntString, FloatString and
+// FloatOrString, so null/bool/string/number validation behaves identically
+// across all the polymorphic scalar types.
+func (v *ScalarJSON) UnmarshalJSON(raw []byte) error {
+	kind, val, err := scanScalar(raw)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case ScalarNull:
+		*v = ScalarJSON{kind: ScalarNull}
+	case ScalarBool:
+		*v = ScalarJSONFromBool(bytes.Equal(val, []byte("true")))
+	case ScalarString:
+		*v = ScalarJSONFromString(string(val))
+	case ScalarNumber:
+		*v = ScalarJSON{kind: ScalarNumber, n: json.Number(val)}
+	default:
+		return fmt.Errorf("sdk: unrecognized JSON token for ScalarJSON: %q", val)
+	}
+	return nil
+}
+
+// MarshalJSON re-emits v using its original token kind.
+func (v ScalarJSON) MarshalJSON() ([]byte, error) {
+	switch v.kind {
+	case ScalarNull:
+		return []byte("null"), nil
+	case ScalarBool:
+		return strconv.AppendBool([]byte{}, v.b), nil
+	case ScalarNumber:
+		return []byte(v.n.String()), nil
+	case ScalarString:
+		return json.Marshal(v.s)
+	default:
+		return nil, fmt.Errorf("sdk: unknown ScalarKind %d", v.kind)
+	}
+}
+
+// skipSpace trims the JSON whitespace characters (space, tab, CR, LF) from
+// both ends of raw.
+func skipSpace(raw []byte) []byte {
+	start := 0
+	for start < len(raw) && isJSONSpace(raw[start]) {
+		start++
+	}
+	end := len(raw)
+	for end > start && isJSONSpace(raw[end-1]) {
+		end--
+	}
+	return raw[start:end]
+}
+
+func isJSONSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n':
+		return true
+	default:
+		return false
+	}
+}
+
+// ToScalarJSON converts s to a ScalarJSON.
+func (s BoolString) ToScalarJSON() ScalarJSON {
+	if s.Valid {
+		return ScalarJSONFromString(s.String)
+	}
+	return ScalarJSONFromBool(s.Flag)
+}
+
+// FromScalarJSON replaces s's contents with v's.
+func (s *BoolString) FromScalarJSON(v ScalarJSON) error {
+	switch v.Kind() {
+	case ScalarString:
+		str, _ := v.AsString()
+		*s = BoolStringFrom(str)
+	case ScalarBool:
+		b, _ := v.AsBool()
+		*s = BoolString{Flag: b}
+	case ScalarNull:
+		*s = BoolString{}
+	default:
+		return fmt.Errorf("sdk: cannot convert ScalarKind %d to BoolString", v.Kind())
+	}
+	return nil
+}
+
+// ToScalarJSON converts s to a ScalarJSON.
+func (s BoolInt) ToScalarJSON() ScalarJSON {
+	if s.Valid {
+		return ScalarJSONFromInt64(s.Int64)
+	}
+	return ScalarJSONFromBool(s.Flag)
+}
+
+// FromScalarJSON replaces s's contents with v's.
+func (s *BoolInt) FromScalarJSON(v ScalarJSON) error {
+	switch v.Kind() {
+	case ScalarNumber:
+		i, ok := v.AsInt64()
+		if !ok {
+			return fmt.Errorf("sdk: ScalarJSON number is not representable as int64")
+		}
+		*s = BoolIntFrom(i)
+	case ScalarBool:
+		b, _ := v.AsBool()
+		*s = BoolInt{Flag: b}
+	case ScalarNull:
+		*s = BoolInt{}
+	default:
+		return fmt.Errorf("sdk: cannot convert ScalarKind %d to BoolInt", v.Kind())
+	}
+	return nil
+}
+
+// ToScalarJSON converts v to a ScalarJSON.
+func (v IntString) ToScalarJSON() ScalarJSON {
+	if !v.Valid {
+		return ScalarJSON{kind: ScalarNull}
+	}
+	return ScalarJSONFromInt64(v.Int64)
+}
+
+// FromScalarJSON replaces v's contents with s's.
+func (v *IntString) FromScalarJSON(s ScalarJSON) error {
+	switch s.Kind() {
+	case ScalarNumber:
+		i, ok := s.AsInt64()
+		if !ok {
+			return fmt.Errorf("sdk: ScalarJSON number is not representable as int64")
+		}
+		*v = IntStringFrom(i)
+	case ScalarNull:
+		*v = IntString{}
+	default:
+		return fmt.Errorf("sdk: cannot convert ScalarKind %d to IntString", s.Kind())
+	}
+	return nil
+}
+
+// ToScalarJSON converts v to a ScalarJSON.
+func (v FloatString) ToScalarJSON() ScalarJSON {
+	if !v.Valid {
+		return ScalarJSON{kind: ScalarNull}
+	}
+	return ScalarJSONFromFloat64(v.Float64)
+}
+
+// FromScalarJSON replaces v's contents with s's.
+func (v *FloatString) FromScalarJSON(s ScalarJSON) error {
+	switch s.Kind() {
+	case ScalarNumber:
+		f, ok := s.AsFloat64()
+		if !ok {
+			return fmt.Errorf("sdk: ScalarJSON number is not representable as float64")
+		}
+		*v = FloatStringFrom(f)
+	case ScalarNull:
+		*v = FloatString{}
+	default:
+		return fmt.Errorf("sdk: cannot convert ScalarKind %d to FloatString", s.Kind())
+	}
+	return nil
+}
+
+// ToScalarJSON converts v to a ScalarJSON.
+func (v FloatOrString) ToScalarJSON() ScalarJSON {
+	switch v.actual {
+	case actualFloat:
+		return ScalarJSONFromFloat64(v.FValue)
+	case actualString:
+		return ScalarJSONFromString(v.SValue)
+	default:
+		return ScalarJSON{kind: ScalarNull}
+	}
+}
+
+// FromScalarJSON replaces v's contents with s's.
+func (v *FloatOrString) FromScalarJSON(s ScalarJSON) error {
+	switch s.Kind() {
+	case ScalarNumber:
+		f, ok := s.AsFloat64()
+		if !ok {
+			return fmt.Errorf("sdk: ScalarJSON number is not representable as float64")
+		}
+		*v = FloatOrStringFromFloat(f)
+	case ScalarString:
+		str, _ := s.AsString()
+		*v = FloatOrStringFromString(str)
+	case ScalarNull:
+		*v = FloatOrString{actual: actualNull}
+	default:
+		return fmt.Errorf("sdk: cannot convert ScalarKind %d to FloatOrString", s.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// jsonNumber matches a JSON number token per the encoding/json grammar, so
+// scanScalar rejects malformed numbers (e.g. "1.2.3", "1e", "01") instead of
+// trusting anything starting with a digit or '-'.
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// unquoteNumber strips surrounding quotes from a Google API-style quoted
+// JSON number, e.g. `"100"` becomes `100`. Bare numbers are left untouched.
+func unquoteNumber(raw []byte) []byte {
+	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
+		return raw[1 : len(raw)-1]
+	}
+	return raw
+}
+
+// scanScalar classifies a single JSON scalar token by its first non-space
+// byte and returns its kind together with the token's raw value: for
+// ScalarBool the literal `true`/`false` bytes, for ScalarNumber the number's
+// bytes verbatim, for ScalarString the unescaped string content, and for
+// ScalarNull a nil value. It is the shared tokenizer behind BoolString,
+// BoolInt, IntString, FloatString and FloatOrString, replacing their
+// previous ad-hoc byte-prefix checks so quoted numbers, escaped strings and
+// surrounding whitespace are all handled in one place.
+func scanScalar(raw []byte) (kind ScalarKind, value []byte, err error) {
+	trimmed := skipSpace(raw)
+	if len(trimmed) == 0 {
+		return ScalarNull, nil, nil
+	}
+
+	switch c := trimmed[0]; {
+	case c == 'n':
+		if !bytes.Equal(trimmed, []byte("null")) {
+			return ScalarNull, nil, fmt.Errorf("sdk: invalid JSON token %q", trimmed)
+		}
+		return ScalarNull, nil, nil
+	case c == 't' || c == 'f':
+		var b bool
+		if err := json.Unmarshal(trimmed, &b); err != nil {
+			return ScalarNull, nil, err
+		}
+		return ScalarBool, trimmed, nil
+	case c == '"':
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return ScalarNull, nil, err
+		}
+		return ScalarString, []byte(s), nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		if !jsonNumber.Match(trimmed) {
+			return ScalarNull, nil, fmt.Errorf("sdk: invalid JSON number %q", trimmed)
+		}
+		return ScalarNumber, trimmed, nil
+	default:
+		return ScalarNull, nil, fmt.Errorf("sdk: unrecognized JSON token %q", trimmed)
+	}
+}
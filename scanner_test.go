@@ -0,0 +1,101 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// FuzzScanScalar checks scanScalar against encoding/json.Unmarshal's own
+// behavior for the equivalent plain Go type, for every kind it can report.
+// Whenever scanScalar accepts a token, the stdlib decoder must accept it too
+// and, for bools, strings and null, agree on the decoded value (numbers are
+// checked for acceptance only); this is what guards against the scanner
+// drifting from JSON's actual grammar (malformed numbers, bad escapes,
+// unterminated strings, etc.).
+func FuzzScanScalar(f *testing.F) {
+	for _, seed := range []string{
+		"true", "false", "null",
+		" true ", "\tfalse\n", "\nnull\n",
+		`"hello"`, `"he said \"hi\""`, `"line\nbreak"`, `"é"`, `""`,
+		"100", "-100", "0", "-0", "1.5", "-1.5e10", "1e-3",
+		"1.2.3", "1e", "01", "-", "--1", "1.", ".5",
+		"", "nul", "nonsense", `"unterminated`, "tru", "TRUE",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		raw := []byte(s)
+		kind, val, err := scanScalar(raw)
+
+		switch kind {
+		case ScalarBool:
+			if err != nil {
+				return
+			}
+			var want bool
+			if jerr := json.Unmarshal(val, &want); jerr != nil {
+				t.Fatalf("scanScalar(%q) accepted as bool %q, but encoding/json rejected it: %v", s, val, jerr)
+			}
+			if got := string(val) == "true"; got != want {
+				t.Fatalf("scanScalar(%q) = %v, encoding/json = %v", s, got, want)
+			}
+		case ScalarString:
+			if err != nil {
+				return
+			}
+			trimmed := skipSpace(raw)
+			var want string
+			if jerr := json.Unmarshal(trimmed, &want); jerr != nil {
+				t.Fatalf("scanScalar(%q) accepted as string, but encoding/json rejected %q: %v", s, trimmed, jerr)
+			}
+			if string(val) != want {
+				t.Fatalf("scanScalar(%q) = %q, encoding/json = %q", s, val, want)
+			}
+		case ScalarNumber:
+			if err != nil {
+				return
+			}
+			// Compare grammar, not range: stdlib rejects e.g. 1e700 as
+			// "out of range" for float64, which is a magnitude check, not
+			// a tokenizing one, so validate via json.Number instead.
+			var want json.Number
+			d := json.NewDecoder(bytes.NewReader(val))
+			d.UseNumber()
+			if jerr := d.Decode(&want); jerr != nil {
+				t.Fatalf("scanScalar(%q) accepted as number %q, but encoding/json rejected it: %v", s, val, jerr)
+			}
+		case ScalarNull:
+			if err != nil {
+				return
+			}
+			trimmed := skipSpace(raw)
+			if len(trimmed) == 0 {
+				return
+			}
+			var want interface{}
+			if jerr := json.Unmarshal(trimmed, &want); jerr != nil || want != nil {
+				t.Fatalf("scanScalar(%q) = null, but encoding/json disagrees (err=%v, want=%v)", s, jerr, want)
+			}
+		}
+	})
+}
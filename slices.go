@@ -0,0 +1,211 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// IntStringSlice is a slice of int64 that decodes Grafana-style ID arrays
+// where elements may be quoted strings or bare JSON numbers, even mixed
+// within the same array (e.g. `["100","101"]` or `[100,101]`). It marshals
+// back as quoted strings, matching the wire format Grafana and Google APIs
+// use to dodge JavaScript's 53-bit integer limit.
+type IntStringSlice []int64
+
+// UnmarshalJSON accepts an array of quoted or bare integers. A JSON null
+// leaves s nil, matching how a plain []int64 round-trips null.
+func (s *IntStringSlice) UnmarshalJSON(raw []byte) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return err
+	}
+	if elems == nil {
+		*s = nil
+		return nil
+	}
+	out := make(IntStringSlice, len(elems))
+	for i, elem := range elems {
+		v, err := strconv.ParseInt(string(unquoteNumber(elem)), 10, 64)
+		if err != nil {
+			return fmt.Errorf("sdk: IntStringSlice[%d]: %w", i, err)
+		}
+		out[i] = v
+	}
+	*s = out
+	return nil
+}
+
+// MarshalJSON emits s as an array of quoted integers.
+func (s IntStringSlice) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, v := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('"')
+		buf.WriteString(strconv.FormatInt(v, 10))
+		buf.WriteByte('"')
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// Scan implements the sql.Scanner interface, reading s back from a JSON
+// column.
+func (s *IntStringSlice) Scan(value interface{}) error {
+	return scanJSONColumn(value, s)
+}
+
+// Value implements the driver.Valuer interface, storing s as a JSON column.
+func (s IntStringSlice) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Int64Slice is a slice of int64 that decodes Grafana-style ID arrays where
+// elements may be quoted strings or bare JSON numbers, even mixed within
+// the same array. Unlike IntStringSlice, it marshals back as bare JSON
+// numbers; use it when the consumer expects plain numeric output.
+type Int64Slice []int64
+
+// UnmarshalJSON accepts an array of quoted or bare integers. A JSON null
+// leaves s nil, matching how a plain []int64 round-trips null.
+func (s *Int64Slice) UnmarshalJSON(raw []byte) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return err
+	}
+	if elems == nil {
+		*s = nil
+		return nil
+	}
+	out := make(Int64Slice, len(elems))
+	for i, elem := range elems {
+		v, err := strconv.ParseInt(string(unquoteNumber(elem)), 10, 64)
+		if err != nil {
+			return fmt.Errorf("sdk: Int64Slice[%d]: %w", i, err)
+		}
+		out[i] = v
+	}
+	*s = out
+	return nil
+}
+
+// MarshalJSON emits s as an array of bare integers.
+func (s Int64Slice) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]int64(s))
+}
+
+// Scan implements the sql.Scanner interface, reading s back from a JSON
+// column.
+func (s *Int64Slice) Scan(value interface{}) error {
+	return scanJSONColumn(value, s)
+}
+
+// Value implements the driver.Valuer interface, storing s as a JSON column.
+func (s Int64Slice) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// FloatStringSlice is a slice of float64 that decodes Grafana-style value
+// arrays where elements may be quoted strings or bare JSON numbers, even
+// mixed within the same array. It marshals back as quoted strings.
+type FloatStringSlice []float64
+
+// UnmarshalJSON accepts an array of quoted or bare numbers. A JSON null
+// leaves s nil, matching how a plain []float64 round-trips null.
+func (s *FloatStringSlice) UnmarshalJSON(raw []byte) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return err
+	}
+	if elems == nil {
+		*s = nil
+		return nil
+	}
+	out := make(FloatStringSlice, len(elems))
+	for i, elem := range elems {
+		v, err := strconv.ParseFloat(string(unquoteNumber(elem)), 64)
+		if err != nil {
+			return fmt.Errorf("sdk: FloatStringSlice[%d]: %w", i, err)
+		}
+		out[i] = v
+	}
+	*s = out
+	return nil
+}
+
+// MarshalJSON emits s as an array of quoted numbers.
+func (s FloatStringSlice) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, v := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('"')
+		buf.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		buf.WriteByte('"')
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// Scan implements the sql.Scanner interface, reading s back from a JSON
+// column.
+func (s *FloatStringSlice) Scan(value interface{}) error {
+	return scanJSONColumn(value, s)
+}
+
+// Value implements the driver.Valuer interface, storing s as a JSON column.
+func (s FloatStringSlice) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// scanJSONColumn unmarshals a database column (stored as []byte or string)
+// into dst via dst's own UnmarshalJSON. A SQL NULL resets dst to nil,
+// matching the "JSON null leaves s nil" guarantee of the JSON path instead
+// of leaving a reused slice variable holding a prior row's contents.
+func scanJSONColumn(value interface{}, dst json.Unmarshaler) error {
+	if value == nil {
+		switch d := dst.(type) {
+		case *IntStringSlice:
+			*d = nil
+		case *Int64Slice:
+			*d = nil
+		case *FloatStringSlice:
+			*d = nil
+		}
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		return dst.UnmarshalJSON(v)
+	case string:
+		return dst.UnmarshalJSON([]byte(v))
+	default:
+		return fmt.Errorf("sdk: cannot scan type %T into %T", value, dst)
+	}
+}
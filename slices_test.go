@@ -0,0 +1,244 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIntStringSliceUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name    string
+		json    string
+		want    IntStringSlice
+		wantErr string
+	}{
+		{"quoted", `["100","101"]`, IntStringSlice{100, 101}, ""},
+		{"bare", `[100,101]`, IntStringSlice{100, 101}, ""},
+		{"mixed", `[100,"101"]`, IntStringSlice{100, 101}, ""},
+		{"empty array", `[]`, IntStringSlice{}, ""},
+		{"null", `null`, nil, ""},
+		{"bad element", `[100,"nope"]`, nil, "IntStringSlice[1]"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var s IntStringSlice
+			err := s.UnmarshalJSON([]byte(c.json))
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("UnmarshalJSON(%q) error = %v, want containing %q", c.json, err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalJSON(%q): %v", c.json, err)
+			}
+			if !reflect.DeepEqual(s, c.want) {
+				t.Fatalf("UnmarshalJSON(%q) = %#v, want %#v", c.json, s, c.want)
+			}
+		})
+	}
+}
+
+func TestIntStringSliceMarshalJSON(t *testing.T) {
+	s := IntStringSlice{100, 101}
+	b, err := s.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON(): %v", err)
+	}
+	if got, want := string(b), `["100","101"]`; got != want {
+		t.Fatalf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestIntStringSliceScanValue(t *testing.T) {
+	s := IntStringSlice{100, 101}
+	val, err := s.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+
+	var got IntStringSlice
+	got = IntStringSlice{999} // simulate a reused variable from a prior row
+	if err := got.Scan(val); err != nil {
+		t.Fatalf("Scan(%v): %v", val, err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Fatalf("Scan(Value()) = %#v, want %#v", got, s)
+	}
+
+	if err := got.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil): %v", err)
+	}
+	if got != nil {
+		t.Fatalf("Scan(nil) left %#v, want nil", got)
+	}
+}
+
+func TestInt64SliceUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name    string
+		json    string
+		want    Int64Slice
+		wantErr string
+	}{
+		{"quoted", `["100","101"]`, Int64Slice{100, 101}, ""},
+		{"bare", `[100,101]`, Int64Slice{100, 101}, ""},
+		{"mixed", `["100",101]`, Int64Slice{100, 101}, ""},
+		{"null", `null`, nil, ""},
+		{"bad element", `["nope",100]`, nil, "Int64Slice[0]"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var s Int64Slice
+			err := s.UnmarshalJSON([]byte(c.json))
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("UnmarshalJSON(%q) error = %v, want containing %q", c.json, err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalJSON(%q): %v", c.json, err)
+			}
+			if !reflect.DeepEqual(s, c.want) {
+				t.Fatalf("UnmarshalJSON(%q) = %#v, want %#v", c.json, s, c.want)
+			}
+		})
+	}
+}
+
+func TestInt64SliceMarshalJSON(t *testing.T) {
+	s := Int64Slice{100, 101}
+	b, err := s.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON(): %v", err)
+	}
+	if got, want := string(b), `[100,101]`; got != want {
+		t.Fatalf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestInt64SliceScanValue(t *testing.T) {
+	s := Int64Slice{100, 101}
+	val, err := s.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+
+	got := Int64Slice{999}
+	if err := got.Scan(val); err != nil {
+		t.Fatalf("Scan(%v): %v", val, err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Fatalf("Scan(Value()) = %#v, want %#v", got, s)
+	}
+
+	if err := got.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil): %v", err)
+	}
+	if got != nil {
+		t.Fatalf("Scan(nil) left %#v, want nil", got)
+	}
+}
+
+func TestFloatStringSliceUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name    string
+		json    string
+		want    FloatStringSlice
+		wantErr string
+	}{
+		{"quoted", `["1.5","2.5"]`, FloatStringSlice{1.5, 2.5}, ""},
+		{"bare", `[1.5,2.5]`, FloatStringSlice{1.5, 2.5}, ""},
+		{"mixed", `[1.5,"2.5"]`, FloatStringSlice{1.5, 2.5}, ""},
+		{"null", `null`, nil, ""},
+		{"bad element", `[1.5,"nope"]`, nil, "FloatStringSlice[1]"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var s FloatStringSlice
+			err := s.UnmarshalJSON([]byte(c.json))
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("UnmarshalJSON(%q) error = %v, want containing %q", c.json, err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalJSON(%q): %v", c.json, err)
+			}
+			if !reflect.DeepEqual(s, c.want) {
+				t.Fatalf("UnmarshalJSON(%q) = %#v, want %#v", c.json, s, c.want)
+			}
+		})
+	}
+}
+
+func TestFloatStringSliceMarshalJSON(t *testing.T) {
+	s := FloatStringSlice{1.5, 2.5}
+	b, err := s.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON(): %v", err)
+	}
+	if got, want := string(b), `["1.5","2.5"]`; got != want {
+		t.Fatalf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestFloatStringSliceScanValue(t *testing.T) {
+	s := FloatStringSlice{1.5, 2.5}
+	val, err := s.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+
+	got := FloatStringSlice{999}
+	if err := got.Scan(val); err != nil {
+		t.Fatalf("Scan(%v): %v", val, err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Fatalf("Scan(Value()) = %#v, want %#v", got, s)
+	}
+
+	if err := got.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil): %v", err)
+	}
+	if got != nil {
+		t.Fatalf("Scan(nil) left %#v, want nil", got)
+	}
+}
+
+func TestScanJSONColumnString(t *testing.T) {
+	var s IntStringSlice
+	if err := s.Scan(`["100"]`); err != nil {
+		t.Fatalf("Scan(string): %v", err)
+	}
+	if !reflect.DeepEqual(s, IntStringSlice{100}) {
+		t.Fatalf("Scan(string) = %#v, want %#v", s, IntStringSlice{100})
+	}
+
+	var bad IntStringSlice
+	if err := bad.Scan(42); err == nil {
+		t.Fatalf("Scan(42): want error, got none")
+	}
+}
@@ -0,0 +1,170 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"strconv"
+)
+
+// MarshalText and UnmarshalText let BoolString, BoolInt, IntString,
+// FloatString and FloatOrString flow through gopkg.in/yaml.v3, TOML, env-var
+// decoders and URL query parsing, not just encoding/json, which matters for
+// Grafana provisioning files that embed the same polymorphic-scalar fields
+// used in dashboard JSON. Each type's canonical textual form mirrors its
+// JSON form with the quoting stripped: bare `true`/`false` for the Bool
+// variant, the bare number or string otherwise, and the empty string for a
+// null IntString, FloatString or FloatOrString. A null BoolString or BoolInt
+// is the same Go value as Flag=false and marshals as `false`.
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// The text form carries no type tag, so a string value that happens to
+// read "true", "false" or "" is indistinguishable from the Flag/null
+// variants on the next UnmarshalText and round-trips as that variant
+// instead. This is the ambiguity inherent to plain-text formats (YAML, TOML,
+// env vars, query params); BoolString's JSON form has no such ambiguity,
+// since MarshalJSON quotes the string variant and UnmarshalJSON keeps a
+// quoted "true" as a string.
+func (s BoolString) MarshalText() ([]byte, error) {
+	if s.Valid {
+		return []byte(s.String), nil
+	}
+	return strconv.AppendBool([]byte{}, s.Flag), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *BoolString) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = BoolString{}
+		return nil
+	}
+	switch string(text) {
+	case "true":
+		*s = BoolString{Flag: true}
+	case "false":
+		*s = BoolString{}
+	default:
+		*s = BoolStringFrom(string(text))
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BoolInt) MarshalText() ([]byte, error) {
+	if s.Valid {
+		return strconv.AppendInt([]byte{}, s.Int64, 10), nil
+	}
+	return strconv.AppendBool([]byte{}, s.Flag), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *BoolInt) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = BoolInt{}
+		return nil
+	}
+	switch string(text) {
+	case "true":
+		*s = BoolInt{Flag: true}
+		return nil
+	case "false":
+		*s = BoolInt{}
+		return nil
+	}
+	i, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil {
+		return err
+	}
+	*s = BoolIntFrom(i)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v IntString) MarshalText() ([]byte, error) {
+	if !v.Valid {
+		return []byte{}, nil
+	}
+	return strconv.AppendInt([]byte{}, v.Int64, 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *IntString) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = IntString{}
+		return nil
+	}
+	i, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil {
+		return err
+	}
+	*v = IntStringFrom(i)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v FloatString) MarshalText() ([]byte, error) {
+	if !v.Valid {
+		return []byte{}, nil
+	}
+	return []byte(strconv.FormatFloat(v.Float64, 'g', -1, 64)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *FloatString) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = FloatString{}
+		return nil
+	}
+	f, err := strconv.ParseFloat(string(text), 64)
+	if err != nil {
+		return err
+	}
+	*v = FloatStringFrom(f)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v FloatOrString) MarshalText() ([]byte, error) {
+	switch v.actual {
+	case actualFloat:
+		return []byte(strconv.FormatFloat(v.FValue, 'g', -1, 64)), nil
+	case actualString:
+		return []byte(v.SValue), nil
+	default:
+		return []byte{}, nil
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. A value that parses as
+// a float is stored as one; anything else is kept as a string. Unlike
+// UnmarshalJSON, which keeps every quoted token as a string, a numeric
+// string such as "100" therefore comes back as the float variant.
+func (v *FloatOrString) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = FloatOrString{actual: actualNull}
+		return nil
+	}
+	if f, err := strconv.ParseFloat(string(text), 64); err == nil {
+		*v = FloatOrStringFromFloat(f)
+		return nil
+	}
+	*v = FloatOrStringFromString(string(text))
+	return nil
+}
@@ -0,0 +1,203 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import "testing"
+
+// roundTripper is implemented by every polymorphic scalar: JSON in, text
+// out, text back in, JSON back out.
+type roundTripper interface {
+	UnmarshalJSON([]byte) error
+	MarshalJSON() ([]byte, error)
+	MarshalText() ([]byte, error)
+	UnmarshalText([]byte) error
+}
+
+// runRoundTrip feeds json into fresh, decodes it, re-encodes as JSON and as
+// text, decodes the text into another fresh value, and checks that the two
+// values agree on both their JSON and text forms. wantText is the type's
+// canonical text form for json.
+func runRoundTrip(t *testing.T, fresh func() roundTripper, json, wantText string) {
+	t.Helper()
+
+	v := fresh()
+	if err := v.UnmarshalJSON([]byte(json)); err != nil {
+		t.Fatalf("UnmarshalJSON(%q): %v", json, err)
+	}
+
+	text, err := v.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText after UnmarshalJSON(%q): %v", json, err)
+	}
+	if string(text) != wantText {
+		t.Fatalf("MarshalText after UnmarshalJSON(%q) = %q, want %q", json, text, wantText)
+	}
+
+	v2 := fresh()
+	if err := v2.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText(%q): %v", text, err)
+	}
+	text2, err := v2.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText after UnmarshalText(%q): %v", text, err)
+	}
+	if string(text2) != string(text) {
+		t.Fatalf("JSON->text->text mismatch for %q: got %q via JSON, %q via text", json, text, text2)
+	}
+
+	gotJSON, err := v.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON after UnmarshalJSON(%q): %v", json, err)
+	}
+	wantJSON, err := v2.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON after UnmarshalText(%q): %v", text, err)
+	}
+	if string(gotJSON) != string(wantJSON) {
+		t.Fatalf("JSON->text->JSON mismatch for %q: got %q via JSON, %q via text", json, gotJSON, wantJSON)
+	}
+}
+
+func TestBoolStringRoundTrip(t *testing.T) {
+	fresh := func() roundTripper { return new(BoolString) }
+	cases := []struct {
+		name, json, text string
+	}{
+		{"flag true", "true", "true"},
+		{"flag false", "false", "false"},
+		{"string", `"enabled"`, "enabled"},
+		// A zero BoolString is indistinguishable from Flag=false: both
+		// UnmarshalJSON("null") and UnmarshalJSON("false") produce the
+		// same Go value, so both marshal back as "false".
+		{"null", "null", "false"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) { runRoundTrip(t, fresh, c.json, c.text) })
+	}
+
+	// Known ambiguity (see text.go): a string value equal to "true",
+	// "false" or "" is indistinguishable from the Flag/null variants once
+	// it has gone through text, so JSON->text->JSON does NOT round-trip
+	// for these specific strings.
+	t.Run("ambiguous string value looks like a flag", func(t *testing.T) {
+		var v BoolString
+		if err := v.UnmarshalJSON([]byte(`"true"`)); err != nil {
+			t.Fatal(err)
+		}
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != "true" {
+			t.Fatalf("MarshalText() = %q, want %q", text, "true")
+		}
+		var v2 BoolString
+		if err := v2.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if !v2.Flag || v2.Valid {
+			t.Fatalf("UnmarshalText(%q) = %+v, want the Flag variant, not the string %q", text, v2, v.String)
+		}
+	})
+}
+
+func TestBoolIntRoundTrip(t *testing.T) {
+	fresh := func() roundTripper { return new(BoolInt) }
+	cases := []struct {
+		name, json, text string
+	}{
+		{"flag true", "true", "true"},
+		{"flag false", "false", "false"},
+		{"int", "100", "100"},
+		{"quoted int", `"100"`, "100"},
+		// Same conflation as BoolString: a zero BoolInt and Flag=false are
+		// the same Go value, so both marshal as "false".
+		{"null", "null", "false"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) { runRoundTrip(t, fresh, c.json, c.text) })
+	}
+}
+
+func TestIntStringRoundTrip(t *testing.T) {
+	fresh := func() roundTripper { return new(IntString) }
+	cases := []struct {
+		name, json, text string
+	}{
+		{"bare int", "100", "100"},
+		{"quoted int", `"100"`, "100"},
+		{"negative", "-42", "-42"},
+		{"null", "null", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) { runRoundTrip(t, fresh, c.json, c.text) })
+	}
+}
+
+func TestFloatStringRoundTrip(t *testing.T) {
+	fresh := func() roundTripper { return new(FloatString) }
+	cases := []struct {
+		name, json, text string
+	}{
+		{"bare float", "1.5", "1.5"},
+		{"quoted float", `"1.5"`, "1.5"},
+		{"negative", "-0.25", "-0.25"},
+		{"null", "null", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) { runRoundTrip(t, fresh, c.json, c.text) })
+	}
+}
+
+func TestFloatOrStringRoundTrip(t *testing.T) {
+	fresh := func() roundTripper { return new(FloatOrString) }
+	cases := []struct {
+		name, json, text string
+	}{
+		{"float", "100.3", "100.3"},
+		{"non-numeric string", `"100px"`, "100px"},
+		{"null", "null", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) { runRoundTrip(t, fresh, c.json, c.text) })
+	}
+
+	// Known ambiguity: a quoted string that happens to parse as a float
+	// (e.g. "100") is indistinguishable from the float variant once it has
+	// gone through text, since UnmarshalText only keeps a string when it
+	// fails to parse as a number.
+	t.Run("ambiguous numeric string looks like a float", func(t *testing.T) {
+		v := FloatOrStringFromString("100")
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != "100" {
+			t.Fatalf("MarshalText() = %q, want %q", text, "100")
+		}
+		var v2 FloatOrString
+		if err := v2.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if v2.actual != actualFloat || v2.FValue != 100 {
+			t.Fatalf("UnmarshalText(%q) = %+v, want the float variant", text, v2)
+		}
+	})
+}